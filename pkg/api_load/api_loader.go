@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"sync"
+	"time"
+)
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+// ApiLoader loads model.Api configuration from a single source (file,
+// registry center, ...) and optionally watches it for changes.
+type ApiLoader interface {
+	// InitLoad performs the first, blocking load of the source.
+	InitLoad() error
+	// HotLoad starts watching the source for changes and returns a
+	// channel that receives a notification every time the source content
+	// may have changed. The channel is closed once watching stops.
+	HotLoad() (chan struct{}, error)
+	// GetLoadedApiConfigs returns the apis known as of the last
+	// InitLoad/HotLoad notification.
+	GetLoadedApiConfigs() ([]model.Api, error)
+	// GetPrior returns this loader's merge priority; lower sorts first in
+	// DoMergeApiTask, so later (higher-priority) loaders win conflicts.
+	GetPrior() int
+}
+
+// LoaderFactory builds an ApiLoader from a model.LoaderConfig entry.
+type LoaderFactory func(cfg model.LoaderConfig) (ApiLoader, error)
+
+var (
+	loaderFactoriesLock sync.RWMutex
+	loaderFactories     = make(map[string]LoaderFactory, 8)
+)
+
+// RegisterLoaderFactory registers a named ApiLoader constructor so
+// ApiManager.AddApiLoader can build loaders purely from
+// model.ApiConfig.Loaders, without this package hard-coding every
+// possible source. Built-in loaders (file, nacos, etcd, consul,
+// zookeeper, kubernetes) register themselves from their own init().
+func RegisterLoaderFactory(name string, factory LoaderFactory) {
+	loaderFactoriesLock.Lock()
+	defer loaderFactoriesLock.Unlock()
+	loaderFactories[name] = factory
+}
+
+// GetLoaderFactory looks up a previously registered LoaderFactory.
+func GetLoaderFactory(name string) (LoaderFactory, bool) {
+	loaderFactoriesLock.RLock()
+	defer loaderFactoriesLock.RUnlock()
+	factory, ok := loaderFactories[name]
+	return factory, ok
+}
+
+// decodeApiPayload decodes a single api entry stored in a registry
+// center. YAML is a superset of JSON so one decoder covers both; loaders
+// that need other formats (TOML, protobuf) go through api_load/codec
+// instead.
+func decodeApiPayload(data []byte, api *model.Api) error {
+	return yaml.Unmarshal(data, api)
+}
+
+// maxWatchBackoff caps how long a registry-center loader's watch loop
+// ever waits between retries after a transient error.
+const maxWatchBackoff = 30 * time.Second
+
+// watchBackoff returns how long a watch loop should sleep after its
+// attempt'th consecutive transient error (attempt starts at 0), backing
+// off exponentially from 1s up to maxWatchBackoff so a flaky registry
+// center doesn't get hammered with reconnects.
+func watchBackoff(attempt int) time.Duration {
+	delay := time.Second << uint(attempt)
+	if delay <= 0 || delay > maxWatchBackoff {
+		delay = maxWatchBackoff
+	}
+	return delay
+}
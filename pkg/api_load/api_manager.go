@@ -34,43 +34,135 @@ import (
 type ApiLoadType string
 
 const (
-	File  ApiLoadType = "file"
-	Nacos ApiLoadType = "nacos"
+	File       ApiLoadType = "file"
+	Nacos      ApiLoadType = "nacos"
+	Kubernetes ApiLoadType = "kubernetes"
 )
 
+// defaultDebounceQuietPeriod is how long DoMergeApiTask waits after the
+// last coalesced notification before actually running a merge.
+const defaultDebounceQuietPeriod = 200 * time.Millisecond
+
 type ApiManager struct {
 	mergeLock *sync.RWMutex
-	// rate limiter
-	limiter         *time.Ticker
-	rateLimiterTime time.Duration
-	mergeTask       chan struct{}
+	// mergeSem is a 1-slot semaphore: a non-blocking send means no merge
+	// is running right now, so DoMergeApiTask may proceed; a full channel
+	// means one already is, so the new call is skipped, not queued.
+	mergeSem chan struct{}
+	// mergeTask carries one coalesced "something changed" signal per
+	// debounce cycle; AddMergeTask sends non-blocking, so a full channel
+	// just means a signal is already pending and the new one coalesces
+	// into it instead of erroring.
+	mergeTask chan struct{}
+	// debounceQuiet is how long runDebounce waits, after the last
+	// mergeTask signal, before calling DoMergeApiTask.
+	debounceQuiet time.Duration
+	stopDebounce  chan struct{}
 	// store apiLoaders
 	ApiLoadTypeMap map[ApiLoadType]ApiLoader
 	ads            service.ApiDiscoveryService
+	// lastMerged is the snapshot (keyed by buildApiID) published to ads on
+	// the previous successful merge, used to diff against the next one.
+	lastMerged map[string]model.Api
+	// loaderConfigs remembers each source's model.LoaderConfig (priority,
+	// merge strategy) so DoMergeApiTask can honor it without re-deriving
+	// it from the original config.ApiConfig.
+	loaderConfigs map[ApiLoadType]model.LoaderConfig
 }
 
-func NewApiManager(rateLimiterTime time.Duration, ads service.ApiDiscoveryService) *ApiManager {
-	if rateLimiterTime < time.Millisecond*50 {
-		rateLimiterTime = time.Millisecond * 50
+// NewApiManager builds an ApiManager that debounces hot-reload
+// notifications for debounceQuiet before merging; debounceQuiet below
+// 50ms is bumped up to it, and zero falls back to
+// defaultDebounceQuietPeriod.
+func NewApiManager(debounceQuiet time.Duration, ads service.ApiDiscoveryService) *ApiManager {
+	if debounceQuiet == 0 {
+		debounceQuiet = defaultDebounceQuietPeriod
+	}
+	if debounceQuiet < time.Millisecond*50 {
+		debounceQuiet = time.Millisecond * 50
 	}
 	return &ApiManager{
-		ApiLoadTypeMap:  make(map[ApiLoadType]ApiLoader, 8),
-		mergeTask:       make(chan struct{}, 1),
-		limiter:         time.NewTicker(rateLimiterTime),
-		rateLimiterTime: rateLimiterTime,
-		mergeLock:       &sync.RWMutex{},
-		ads:             ads,
+		ApiLoadTypeMap: make(map[ApiLoadType]ApiLoader, 8),
+		mergeSem:       make(chan struct{}, 1),
+		mergeTask:      make(chan struct{}, 1),
+		debounceQuiet:  debounceQuiet,
+		stopDebounce:   make(chan struct{}),
+		mergeLock:      &sync.RWMutex{},
+		ads:            ads,
+		lastMerged:     make(map[string]model.Api, 8),
+		loaderConfigs:  make(map[ApiLoadType]model.LoaderConfig, 8),
 	}
 }
 
-// add apiLoader by ApiLoadType
+// add apiLoader for every entry in config.Loaders (plus the
+// config.File/config.Nacos/config.Kubernetes shorthands), looking up its ApiLoader by the
+// factory registered for its Name via RegisterLoaderFactory.
 func (al *ApiManager) AddApiLoader(config model.ApiConfig) {
+	for _, loaderConfig := range al.normalizeLoaderConfigs(config) {
+		factory, ok := GetLoaderFactory(loaderConfig.Name)
+		if !ok {
+			logger.Warnf("proxy add apiLoader error: no ApiLoader factory registered for %q", loaderConfig.Name)
+			continue
+		}
+		loader, err := factory(loaderConfig)
+		if err != nil {
+			logger.Errorf("proxy add apiLoader error: build %q apiLoader:%v", loaderConfig.Name, err)
+			continue
+		}
+		apiLoadType := ApiLoadType(loaderConfig.Name)
+		al.ApiLoadTypeMap[apiLoadType] = loader
+		al.loaderConfigs[apiLoadType] = loaderConfig
+	}
+}
+
+// priorityOf returns loaderConfig.Priority for apiLoadType if the operator
+// set one (including an explicit 0), otherwise the loader's own
+// GetPrior() default.
+func (al *ApiManager) priorityOf(apiLoadType ApiLoadType, loader ApiLoader) int {
+	if cfg, ok := al.loaderConfigs[apiLoadType]; ok && cfg.Priority != nil {
+		return *cfg.Priority
+	}
+	return loader.GetPrior()
+}
+
+// mergeStrategyOf returns the configured model.MergeStrategy for
+// apiLoadType, defaulting to model.MergeOverride to preserve the historical
+// "later entries win" behavior.
+func (al *ApiManager) mergeStrategyOf(apiLoadType ApiLoadType) model.MergeStrategy {
+	if cfg, ok := al.loaderConfigs[apiLoadType]; ok && cfg.MergeStrategy != "" {
+		return cfg.MergeStrategy
+	}
+	return model.MergeOverride
+}
+
+// normalizeLoaderConfigs folds the config.File/config.Nacos/config.Kubernetes
+// fields into model.LoaderConfig entries, so AddApiLoader only has one, generic
+// code path to go through.
+func (al *ApiManager) normalizeLoaderConfigs(config model.ApiConfig) []model.LoaderConfig {
+	loaderConfigs := append([]model.LoaderConfig{}, config.Loaders...)
 	if config.File != nil {
-		al.ApiLoadTypeMap[File] = NewFileApiLoader(WithFilePath(config.File.FileApiConfPath))
+		loaderConfigs = append(loaderConfigs, model.LoaderConfig{
+			Name:       string(File),
+			Properties: map[string]string{"path": config.File.FileApiConfPath},
+		})
 	}
 	if config.Nacos != nil {
-		al.ApiLoadTypeMap[Nacos] = NewNacosApiLoader(WithNacosAddress(config.Nacos.Address))
+		loaderConfigs = append(loaderConfigs, model.LoaderConfig{
+			Name:       string(Nacos),
+			Properties: map[string]string{"address": config.Nacos.Address},
+		})
+	}
+	if config.Kubernetes != nil {
+		loaderConfigs = append(loaderConfigs, model.LoaderConfig{
+			Name: string(Kubernetes),
+			Properties: map[string]string{
+				"kubeconfig": config.Kubernetes.KubeconfigPath,
+				"namespace":  config.Kubernetes.Namespace,
+				"selector":   config.Kubernetes.LabelSelector,
+			},
+		})
 	}
+	return loaderConfigs
 }
 
 // nolint
@@ -91,10 +183,6 @@ func (al *ApiManager) StartLoadApi() error {
 		}
 	}
 
-	if al.limiter == nil {
-		return errors.New("proxy won't hot load api since limiter is null.")
-	}
-
 	for _, loader := range al.ApiLoadTypeMap {
 		changeNotifier, err := loader.HotLoad()
 		if err != nil {
@@ -116,113 +204,197 @@ func (al *ApiManager) StartLoadApi() error {
 			}
 		}()
 	}
+
+	go al.runDebounce()
 	return nil
 }
 
-// store a message to mergeTask to notify calling DoMergeApiTask
+// Stop ends the debounce goroutine started by StartLoadApi. It does not
+// stop the individual ApiLoaders' own HotLoad goroutines.
+func (al *ApiManager) Stop() {
+	close(al.stopDebounce)
+}
+
+// AddMergeTask signals that a loader's content may have changed.
+// It never blocks: if a signal is already pending, this one just
+// coalesces into it instead of being an error.
 func (al *ApiManager) AddMergeTask() error {
 	select {
 	case al.mergeTask <- struct{}{}:
 		logger.Debug("added a merge task, waiting to merge api.")
-		break
-	case <-time.After(5 * time.Second):
-		logger.Errorf("add merge task fail:wait timeout.")
-		break
+	default:
+		notificationsCoalesced.Inc()
+		logger.Debug("merge task already pending, coalescing notification.")
 	}
 	return nil
 }
 
-// to merge apis to store in ads.Notice that limiter will limit frequency of merging.
-func (al *ApiManager) SelectMergeApiTask() (err error) {
+// runDebounce owns the single debounce timer: every mergeTask signal
+// (re)starts a debounceQuiet countdown, and only once that countdown
+// elapses without a new signal does it call DoMergeApiTask. This folds
+// any number of notifications arriving within the quiet period into a
+// single merge.
+func (al *ApiManager) runDebounce() {
+	var timer *time.Timer
+	var timerC <-chan time.Time
 	for {
 		select {
-		case <-al.limiter.C:
-			if len(al.mergeTask) > 0 {
-				_, err = al.DoMergeApiTask()
-				if err != nil {
-					logger.Warnf("error merge api task:%v", err)
-				}
+		case <-al.mergeTask:
+			if timer == nil {
+				timer = time.NewTimer(al.debounceQuiet)
+			} else if !timer.Stop() {
+				<-timer.C
 			}
-			//al.limiter.Reset(time.Second)
-			break
-		default:
-			time.Sleep(al.rateLimiterTime / 10)
-			break
+			timer.Reset(al.debounceQuiet)
+			timerC = timer.C
+		case <-timerC:
+			timer = nil
+			timerC = nil
+			if _, err := al.DoMergeApiTask(); err != nil {
+				logger.Warnf("error merge api task:%v", err)
+			}
+		case <-al.stopDebounce:
+			return
 		}
 	}
-	return
 }
 
-// merge apis
+// DoMergeApiTask merges every loader's apis into one set and publishes
+// it to ads. If a previous call is still running, this one is skipped
+// rather than queued; the debounce loop will trigger another merge on
+// the next change anyway.
 func (al *ApiManager) DoMergeApiTask() (skip bool, err error) {
-	al.mergeLock.Lock()
-	defer al.mergeLock.Unlock()
-	wait := time.After(time.Millisecond * 50)
 	select {
-	case <-wait:
-		logger.Debug("merge api task is too frequent.")
+	case al.mergeSem <- struct{}{}:
+		defer func() { <-al.mergeSem }()
+	default:
+		mergesSkippedFrequency.Inc()
+		logger.Debug("merge api task is already running, skip.")
 		skip = true
 		return
-	case <-al.mergeTask:
-		// If apiLoadType is File,then try covering it's apis using other's apis from registry center
-		multiApisMerged := make(map[string]model.Api, 8)
-		var sortedApiLoader []int
-		sortedApiLoaderMap := make(map[int]ApiLoadType, len(al.ApiLoadTypeMap))
-		for apiLoadType, loader := range al.ApiLoadTypeMap {
-			sortedApiLoader = append(sortedApiLoader, loader.GetPrior())
-			sortedApiLoaderMap[loader.GetPrior()] = apiLoadType
-		}
+	}
 
-		sort.Ints(sortedApiLoader)
-		for _, sortNo := range sortedApiLoader {
-			loadType := sortedApiLoaderMap[sortNo]
-			apiLoader := al.ApiLoadTypeMap[loadType]
-			var apiConfigs []model.Api
-			apiConfigs, err = apiLoader.GetLoadedApiConfigs()
-			if err != nil {
-				logger.Error("get file apis error:%v", err)
-				return
-			} else {
-				for _, fleApiConfig := range apiConfigs {
-					if fleApiConfig.Status != model.Up {
-						continue
-					}
-					multiApisMerged[al.buildApiID(fleApiConfig)] = fleApiConfig
-				}
-			}
+	al.mergeLock.Lock()
+	defer al.mergeLock.Unlock()
+
+	start := time.Now()
+	defer func() {
+		mergeDurationSeconds.Observe(time.Since(start).Seconds())
+		if err != nil {
+			lastMergeErrorTimestamp.Set(float64(time.Now().Unix()))
 		}
+	}()
 
-		var totalApis []model.Api
-		for _, api := range multiApisMerged {
-			totalApis = append(totalApis, api)
+	// If apiLoadType is File,then try covering it's apis using other's apis from registry center
+	multiApisMerged := make(map[string]model.Api, 8)
+	idOwner := make(map[string]ApiLoadType, 8)
+	var conflicts []string
+	type prioritizedLoadType struct {
+		prior    int
+		loadType ApiLoadType
+	}
+	sortedApiLoader := make([]prioritizedLoadType, 0, len(al.ApiLoadTypeMap))
+	seenPriority := make(map[int]ApiLoadType, len(al.ApiLoadTypeMap))
+	for apiLoadType, loader := range al.ApiLoadTypeMap {
+		prior := al.priorityOf(apiLoadType, loader)
+		if owner, ok := seenPriority[prior]; ok {
+			logger.Warnf("merge api task: %q and %q both have priority %d; their merge order is unspecified, set distinct priorities to disambiguate", owner, apiLoadType, prior)
 		}
-		err = al.ads.RemoveAllApi()
+		seenPriority[prior] = apiLoadType
+		sortedApiLoader = append(sortedApiLoader, prioritizedLoadType{prior: prior, loadType: apiLoadType})
+	}
+
+	sort.Slice(sortedApiLoader, func(i, j int) bool { return sortedApiLoader[i].prior < sortedApiLoader[j].prior })
+	for _, entry := range sortedApiLoader {
+		loadType := entry.loadType
+		apiLoader := al.ApiLoadTypeMap[loadType]
+		strategy := al.mergeStrategyOf(loadType)
+		var apiConfigs []model.Api
+		apiConfigs, err = apiLoader.GetLoadedApiConfigs()
 		if err != nil {
-			logger.Errorf("remove all older apis error:%v", err)
+			logger.Error("get file apis error:%v", err)
 			return
 		}
-		err = al.add2ApiDiscoveryService(totalApis)
-		if err != nil {
-			logger.Errorf("add newer apis error:%v", err)
-			return
+		for _, fleApiConfig := range apiConfigs {
+			if fleApiConfig.Status != model.Up {
+				continue
+			}
+			id := al.buildApiID(fleApiConfig)
+			_, exists := multiApisMerged[id]
+			switch {
+			case !exists:
+				multiApisMerged[id] = fleApiConfig
+				idOwner[id] = loadType
+			case idOwner[id] == loadType:
+				// same source listed this id twice (e.g. a duplicate route
+				// entry); last one wins, it's not a cross-source conflict
+				multiApisMerged[id] = fleApiConfig
+			case strategy == model.MergeUnion:
+				multiApisMerged[fmt.Sprintf("%s#%s", id, loadType)] = fleApiConfig
+			case strategy == model.MergeFallback:
+				// this source only fills gaps, never replaces what an
+				// earlier (lower-priority) source already published
+			case strategy == model.MergeReject:
+				conflicts = append(conflicts, fmt.Sprintf("%s (kept from %s, rejected from %s)", id, idOwner[id], loadType))
+			default: // model.MergeOverride
+				multiApisMerged[id] = fleApiConfig
+				idOwner[id] = loadType
+			}
 		}
+	}
+	if len(conflicts) > 0 {
+		logger.Errorf("merge api task: %d conflicting apis detected across sources: %v", len(conflicts), conflicts)
+	}
+
+	err = al.diffApiDiscoveryService(multiApisMerged)
+	if err != nil {
+		logger.Errorf("diff and publish apis error:%v", err)
 		return
 	}
+	al.lastMerged = multiApisMerged
+	mergesTotal.Inc()
+	return
 }
 
-// add merged apis to ads
-func (al *ApiManager) add2ApiDiscoveryService(apis []model.Api) error {
-	for _, api := range apis {
+// diffApiDiscoveryService compares newMerged against al.lastMerged and only
+// issues the AddApi/UpdateApi/RemoveApi calls needed to bring ads in sync,
+// instead of RemoveAllApi + re-adding everything on every merge.
+func (al *ApiManager) diffApiDiscoveryService(newMerged map[string]model.Api) error {
+	for id, api := range newMerged {
+		old, existed := al.lastMerged[id]
+		if !existed {
+			j, _ := json.Marshal(api)
+			if _, err := al.ads.AddApi(*service.NewDiscoveryRequest(j)); err != nil {
+				logger.Errorf("error add api:%s", j)
+				return err
+			}
+			continue
+		}
+		if old == api {
+			continue
+		}
 		j, _ := json.Marshal(api)
-		_, err := al.ads.AddApi(*service.NewDiscoveryRequest(j))
-		if err != nil {
-			logger.Errorf("error add api:%s", j)
+		if err := al.ads.UpdateApi(*service.NewDiscoveryRequest(j)); err != nil {
+			logger.Errorf("error update api:%s", j)
+			return err
+		}
+	}
+
+	for id := range al.lastMerged {
+		if _, stillPresent := newMerged[id]; stillPresent {
+			continue
+		}
+		if err := al.ads.RemoveApi(id); err != nil {
+			logger.Errorf("error remove api:%s", id)
 			return err
 		}
 	}
 	return nil
 }
 
+// buildApiID derives a stable identity for an api from its content alone
+// (name/itype/otype/method), so it stays the same across loader restarts
+// and can be used as the diff key between merge cycles.
 // nolint
 func (al *ApiManager) buildApiID(api model.Api) string {
 	return fmt.Sprintf("name:%s,ITypeStr:%s,OTypeStr:%s,Method:%s",
@@ -0,0 +1,166 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/service"
+)
+
+// fakeApiDiscoveryService records how many times each mutation was called,
+// so tests can assert diffApiDiscoveryService only issues the calls it
+// actually needs to.
+type fakeApiDiscoveryService struct {
+	addCalls       int
+	updateCalls    int
+	removeCalls    int
+	removeAllCalls int
+}
+
+func (f *fakeApiDiscoveryService) AddApi(request service.DiscoveryRequest) (string, error) {
+	f.addCalls++
+	return "", nil
+}
+
+func (f *fakeApiDiscoveryService) UpdateApi(request service.DiscoveryRequest) error {
+	f.updateCalls++
+	return nil
+}
+
+func (f *fakeApiDiscoveryService) RemoveApi(id string) error {
+	f.removeCalls++
+	return nil
+}
+
+func (f *fakeApiDiscoveryService) RemoveAllApi() error {
+	f.removeAllCalls++
+	return nil
+}
+
+func TestDiffApiDiscoveryServiceNoMutationOnIdenticalReplay(t *testing.T) {
+	ads := &fakeApiDiscoveryService{}
+	al := NewApiManager(0, ads)
+
+	merged := map[string]model.Api{
+		"name:echo,ITypeStr:json,OTypeStr:json,Method:GET": {
+			Name:     "echo",
+			ITypeStr: "json",
+			OTypeStr: "json",
+			Method:   "GET",
+			Status:   model.Up,
+		},
+	}
+
+	if err := al.diffApiDiscoveryService(merged); err != nil {
+		t.Fatalf("first diff: unexpected error: %v", err)
+	}
+	al.lastMerged = merged
+	if ads.addCalls != 1 {
+		t.Fatalf("first diff: expected 1 AddApi call, got %d", ads.addCalls)
+	}
+
+	if err := al.diffApiDiscoveryService(merged); err != nil {
+		t.Fatalf("second diff: unexpected error: %v", err)
+	}
+
+	if ads.addCalls != 1 {
+		t.Errorf("re-fire with identical content: expected no extra AddApi calls, got %d total", ads.addCalls)
+	}
+	if ads.updateCalls != 0 {
+		t.Errorf("re-fire with identical content: expected no UpdateApi calls, got %d", ads.updateCalls)
+	}
+	if ads.removeCalls != 0 {
+		t.Errorf("re-fire with identical content: expected no RemoveApi calls, got %d", ads.removeCalls)
+	}
+}
+
+// fakeApiLoader is a minimal ApiLoader whose GetLoadedApiConfigs result can
+// be swapped out between merge cycles, so tests can drive runDebounce
+// through more than one real merge without a backing file/registry.
+type fakeApiLoader struct {
+	lock sync.Mutex
+	apis []model.Api
+}
+
+func (f *fakeApiLoader) InitLoad() error { return nil }
+
+func (f *fakeApiLoader) HotLoad() (chan struct{}, error) { return make(chan struct{}), nil }
+
+func (f *fakeApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	apis := make([]model.Api, len(f.apis))
+	copy(apis, f.apis)
+	return apis, nil
+}
+
+func (f *fakeApiLoader) GetPrior() int { return 1 }
+
+func (f *fakeApiLoader) setApis(apis []model.Api) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.apis = apis
+}
+
+// TestRunDebounceSurvivesTwoMergeCycles is a regression test for a
+// deadlock in runDebounce: after the debounce timer fired and was drained
+// via the <-timerC case, `timer` kept pointing at that same, already-fired
+// timer. The next mergeTask signal then took the `!timer.Stop()` branch
+// and unconditionally received from timer.C again, blocking forever on an
+// already-drained channel. That hung runDebounce after exactly one merge,
+// silently disabling hot-reload for the rest of the process's life.
+func TestRunDebounceSurvivesTwoMergeCycles(t *testing.T) {
+	ads := &fakeApiDiscoveryService{}
+	al := NewApiManager(50*time.Millisecond, ads)
+	loader := &fakeApiLoader{}
+	al.ApiLoadTypeMap[File] = loader
+
+	go al.runDebounce()
+	defer al.Stop()
+
+	loader.setApis([]model.Api{{Name: "echo", ITypeStr: "json", OTypeStr: "json", Method: "GET", Status: model.Up}})
+	if err := al.AddMergeTask(); err != nil {
+		t.Fatalf("first AddMergeTask: unexpected error: %v", err)
+	}
+	waitForCondition(t, "first merge cycle never completed", func() bool { return ads.addCalls == 1 })
+
+	loader.setApis([]model.Api{{Name: "echo", ITypeStr: "json", OTypeStr: "json", Method: "POST", Status: model.Up}})
+	if err := al.AddMergeTask(); err != nil {
+		t.Fatalf("second AddMergeTask: unexpected error: %v", err)
+	}
+	waitForCondition(t, "second merge cycle never completed (deadlock)", func() bool { return ads.addCalls == 2 })
+}
+
+// waitForCondition polls cond until it's true or fails the test after a
+// generous timeout, so a regressed deadlock fails the test instead of
+// hanging the whole test binary.
+func waitForCondition(t *testing.T, failMsg string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(failMsg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
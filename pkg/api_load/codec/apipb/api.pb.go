@@ -0,0 +1,62 @@
+// Package apipb is a hand-written protobuf message matching api.proto;
+// there is no protoc/protoc-gen-go in this build, so it is maintained by
+// hand rather than generated. Keep the struct tags and field numbers here
+// in sync with api.proto when one changes.
+package apipb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Api is the protobuf wire representation of model.Api. Keep it in sync
+// with api.proto by hand; see the package doc comment above.
+type Api struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ITypeStr string `protobuf:"bytes,2,opt,name=i_type_str,json=iTypeStr,proto3" json:"i_type_str,omitempty"`
+	OTypeStr string `protobuf:"bytes,3,opt,name=o_type_str,json=oTypeStr,proto3" json:"o_type_str,omitempty"`
+	Method   string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Status   int32  `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *Api) Reset()         { *m = Api{} }
+func (m *Api) String() string { return proto.CompactTextString(m) }
+func (*Api) ProtoMessage()    {}
+
+func (m *Api) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Api) GetITypeStr() string {
+	if m != nil {
+		return m.ITypeStr
+	}
+	return ""
+}
+
+func (m *Api) GetOTypeStr() string {
+	if m != nil {
+		return m.OTypeStr
+	}
+	return ""
+}
+
+func (m *Api) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Api) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Api)(nil), "apipb.Api")
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package codec negotiates which wire format a single model.Api payload
+// was written in, the way Kubernetes negotiates request/response codecs.
+// ApiLoaders pick a Codec by file extension or by a content-type hint and
+// never need to know which formats are actually available.
+package codec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+// Codec decodes model.Api payloads from one wire format.
+type Codec interface {
+	// Decode parses data, written in this Codec's format, into a single api.
+	Decode(data []byte, api *model.Api) error
+	// DecodeList parses data into the list of apis it contains, e.g. a
+	// whole file or a whole nacos config item.
+	DecodeList(data []byte) ([]model.Api, error)
+	// ContentTypes lists the content-type values this Codec answers to,
+	// e.g. "application/yaml".
+	ContentTypes() []string
+	// FileExtensions lists the file extensions (without the leading dot)
+	// this Codec answers to, e.g. "yaml", "yml".
+	FileExtensions() []string
+}
+
+var (
+	lock          sync.RWMutex
+	byExtension   = make(map[string]Codec, 8)
+	byContentType = make(map[string]Codec, 8)
+)
+
+// RegisterCodec makes codec available under every content type and file
+// extension it declares. Built-in codecs (yaml, json, toml, protobuf)
+// register themselves from init(); callers can register their own (HCL,
+// CUE, ...) the same way.
+func RegisterCodec(codec Codec) {
+	lock.Lock()
+	defer lock.Unlock()
+	for _, contentType := range codec.ContentTypes() {
+		byContentType[strings.ToLower(contentType)] = codec
+	}
+	for _, ext := range codec.FileExtensions() {
+		byExtension[strings.ToLower(strings.TrimPrefix(ext, "."))] = codec
+	}
+}
+
+// ForFileExtension looks up the Codec registered for a file extension
+// (with or without its leading dot).
+func ForFileExtension(ext string) (Codec, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	codec, ok := byExtension[strings.ToLower(strings.TrimPrefix(ext, "."))]
+	if !ok {
+		return nil, fmt.Errorf("codec: no codec registered for file extension %q", ext)
+	}
+	return codec, nil
+}
+
+// ForContentType looks up the Codec registered for a content type.
+func ForContentType(contentType string) (Codec, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	codec, ok := byContentType[strings.ToLower(contentType)]
+	if !ok {
+		return nil, fmt.Errorf("codec: no codec registered for content type %q", contentType)
+	}
+	return codec, nil
+}
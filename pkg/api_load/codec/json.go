@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package codec
+
+import (
+	"encoding/json"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec decodes JSON-encoded model.Api payloads.
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(data []byte, api *model.Api) error {
+	return json.Unmarshal(data, api)
+}
+
+func (jsonCodec) DecodeList(data []byte) ([]model.Api, error) {
+	var apis []model.Api
+	if err := json.Unmarshal(data, &apis); err != nil {
+		return nil, err
+	}
+	return apis, nil
+}
+
+func (jsonCodec) ContentTypes() []string {
+	return []string{"application/json"}
+}
+
+func (jsonCodec) FileExtensions() []string {
+	return []string{"json"}
+}
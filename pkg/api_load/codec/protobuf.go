@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package codec
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/api_load/codec/apipb"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterCodec(protobufCodec{})
+}
+
+// protobufCodec decodes model.Api payloads encoded as apipb.Api protobuf
+// messages.
+type protobufCodec struct{}
+
+func (protobufCodec) Decode(data []byte, api *model.Api) error {
+	var wire apipb.Api
+	if err := proto.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	api.Name = wire.Name
+	api.ITypeStr = wire.ITypeStr
+	api.OTypeStr = wire.OTypeStr
+	api.Method = wire.Method
+	api.Status = model.Status(wire.Status)
+	return nil
+}
+
+// DecodeList treats the payload as a single protobuf-encoded api: unlike
+// YAML/JSON/TOML, a .pb file has no established convention for encoding a
+// repeated Api without a dedicated wrapper message, so one file is one api.
+func (c protobufCodec) DecodeList(data []byte) ([]model.Api, error) {
+	var api model.Api
+	if err := c.Decode(data, &api); err != nil {
+		return nil, err
+	}
+	return []model.Api{api}, nil
+}
+
+func (protobufCodec) ContentTypes() []string {
+	return []string{"application/protobuf", "application/x-protobuf"}
+}
+
+func (protobufCodec) FileExtensions() []string {
+	return []string{"pb"}
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package codec
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterCodec(tomlCodec{})
+}
+
+// tomlCodec decodes TOML-encoded model.Api payloads.
+type tomlCodec struct{}
+
+func (tomlCodec) Decode(data []byte, api *model.Api) error {
+	return toml.Unmarshal(data, api)
+}
+
+// DecodeList expects the TOML api list under an "apis" root table, since
+// TOML (unlike YAML/JSON) has no bare top-level array:
+//
+//	[[apis]]
+//	name = "..."
+func (tomlCodec) DecodeList(data []byte) ([]model.Api, error) {
+	var root struct {
+		Apis []model.Api `toml:"apis"`
+	}
+	if err := toml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return root.Apis, nil
+}
+
+func (tomlCodec) ContentTypes() []string {
+	return []string{"application/toml"}
+}
+
+func (tomlCodec) FileExtensions() []string {
+	return []string{"toml"}
+}
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package codec
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+import (
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterCodec(yamlCodec{})
+}
+
+// yamlCodec decodes YAML-encoded model.Api payloads. It is also the
+// default codec callers fall back to when no content-type hint is given.
+type yamlCodec struct{}
+
+func (yamlCodec) Decode(data []byte, api *model.Api) error {
+	return yaml.Unmarshal(data, api)
+}
+
+func (yamlCodec) DecodeList(data []byte) ([]model.Api, error) {
+	var apis []model.Api
+	if err := yaml.Unmarshal(data, &apis); err != nil {
+		return nil, err
+	}
+	return apis, nil
+}
+
+func (yamlCodec) ContentTypes() []string {
+	return []string{"application/yaml", "text/yaml"}
+}
+
+func (yamlCodec) FileExtensions() []string {
+	return []string{"yaml", "yml"}
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"sync"
+	"time"
+)
+
+import (
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterLoaderFactory("consul", newConsulApiLoaderFromConfig)
+}
+
+func newConsulApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	return NewConsulApiLoader(cfg.Properties["address"], cfg.Properties["prefix"])
+}
+
+// ConsulApiLoader loads apis from a key prefix in Consul's KV store, one
+// api per key, and keeps watching it via Consul's blocking queries.
+type ConsulApiLoader struct {
+	client *consulapi.Client
+	prefix string
+
+	lock      sync.RWMutex
+	apis      []model.Api
+	lastIndex uint64
+}
+
+// NewConsulApiLoader connects to the given Consul agent and prepares to
+// load apis stored under prefix in its KV store.
+func NewConsulApiLoader(address, prefix string) (*ConsulApiLoader, error) {
+	client, err := consulapi.NewClient(&consulapi.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+	return &ConsulApiLoader{client: client, prefix: prefix}, nil
+}
+
+func (c *ConsulApiLoader) InitLoad() error {
+	pairs, meta, err := c.client.KV().List(c.prefix, nil)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	c.apis = decodeConsulPairs(pairs)
+	c.lastIndex = meta.LastIndex
+	c.lock.Unlock()
+	return nil
+}
+
+// HotLoad polls Consul's blocking query endpoint, which only returns once
+// the prefix's index has moved past lastIndex (or the query times out).
+// A transient query error is retried with backoff instead of ending the
+// watch: Consul being briefly unreachable shouldn't permanently disable
+// hot-reload for this source until the process restarts.
+func (c *ConsulApiLoader) HotLoad() (chan struct{}, error) {
+	changeNotifier := make(chan struct{}, 1)
+	go func() {
+		defer close(changeNotifier)
+		attempt := 0
+		for {
+			c.lock.RLock()
+			waitIndex := c.lastIndex
+			c.lock.RUnlock()
+
+			pairs, meta, err := c.client.KV().List(c.prefix, &consulapi.QueryOptions{WaitIndex: waitIndex})
+			if err != nil {
+				delay := watchBackoff(attempt)
+				attempt++
+				logger.Errorf("consul api loader: blocking query error, retrying in %s: %v", delay, err)
+				time.Sleep(delay)
+				continue
+			}
+			attempt = 0
+			if meta.LastIndex == waitIndex {
+				continue
+			}
+
+			c.lock.Lock()
+			c.apis = decodeConsulPairs(pairs)
+			c.lastIndex = meta.LastIndex
+			c.lock.Unlock()
+
+			select {
+			case changeNotifier <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changeNotifier, nil
+}
+
+func (c *ConsulApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.apis, nil
+}
+
+func (c *ConsulApiLoader) GetPrior() int {
+	return 20
+}
+
+func decodeConsulPairs(pairs consulapi.KVPairs) []model.Api {
+	apis := make([]model.Api, 0, len(pairs))
+	for _, pair := range pairs {
+		var api model.Api
+		if err := decodeApiPayload(pair.Value, &api); err != nil {
+			logger.Warnf("consul api loader: skip undecodable key %s: %v", pair.Key, err)
+			continue
+		}
+		apis = append(apis, api)
+	}
+	return apis
+}
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"go.etcd.io/etcd/clientv3"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterLoaderFactory("etcd", newEtcdApiLoaderFromConfig)
+}
+
+func newEtcdApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	endpoints := strings.Split(cfg.Properties["endpoints"], ",")
+	return NewEtcdApiLoader(endpoints, cfg.Properties["prefix"])
+}
+
+// EtcdApiLoader loads apis from a key prefix in an etcd v3 cluster, one
+// api per key, and watches the prefix for changes.
+type EtcdApiLoader struct {
+	client *clientv3.Client
+	prefix string
+
+	lock sync.RWMutex
+	apis []model.Api
+}
+
+// NewEtcdApiLoader connects to the given etcd endpoints and prepares to
+// load apis stored under prefix.
+func NewEtcdApiLoader(endpoints []string, prefix string) (*EtcdApiLoader, error) {
+	client, err := clientv3.New(clientv3.Config{Endpoints: endpoints})
+	if err != nil {
+		return nil, err
+	}
+	return &EtcdApiLoader{client: client, prefix: prefix}, nil
+}
+
+func (e *EtcdApiLoader) InitLoad() error {
+	resp, err := e.client.Get(context.Background(), e.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	apis := make([]model.Api, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var api model.Api
+		if err := decodeApiPayload(kv.Value, &api); err != nil {
+			logger.Warnf("etcd api loader: skip undecodable key %s: %v", kv.Key, err)
+			continue
+		}
+		apis = append(apis, api)
+	}
+	e.lock.Lock()
+	e.apis = apis
+	e.lock.Unlock()
+	return nil
+}
+
+// HotLoad watches the prefix for changes. If the watch channel breaks
+// (e.g. the etcd connection drops or the watch is canceled server-side),
+// it reconnects with backoff instead of silently and permanently ending
+// the watch: a transient etcd outage shouldn't disable hot-reload for
+// this source until the process restarts.
+func (e *EtcdApiLoader) HotLoad() (chan struct{}, error) {
+	changeNotifier := make(chan struct{}, 1)
+	go func() {
+		defer close(changeNotifier)
+		attempt := 0
+		for {
+			watchChan := e.client.Watch(context.Background(), e.prefix, clientv3.WithPrefix())
+			watchErr := false
+			for resp := range watchChan {
+				if err := resp.Err(); err != nil {
+					logger.Errorf("etcd api loader: watch error, reconnecting: %v", err)
+					watchErr = true
+					break
+				}
+				attempt = 0
+				if err := e.InitLoad(); err != nil {
+					logger.Errorf("etcd api loader: reload after watch event error:%v", err)
+					continue
+				}
+				select {
+				case changeNotifier <- struct{}{}:
+				default:
+				}
+			}
+			if !watchErr {
+				logger.Errorf("etcd api loader: watch channel closed unexpectedly, reconnecting")
+			}
+			delay := watchBackoff(attempt)
+			attempt++
+			time.Sleep(delay)
+		}
+	}()
+	return changeNotifier, nil
+}
+
+func (e *EtcdApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.apis, nil
+}
+
+func (e *EtcdApiLoader) GetPrior() int {
+	return 10
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+import (
+	"github.com/fsnotify/fsnotify"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/api_load/codec"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterLoaderFactory("file", newFileApiLoaderFromConfig)
+}
+
+func newFileApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	return NewFileApiLoader(WithFilePath(cfg.Properties["path"])), nil
+}
+
+// FileApiLoaderOption configures a FileApiLoader.
+type FileApiLoaderOption func(*FileApiLoader)
+
+// WithFilePath sets the path of the api config file to load.
+func WithFilePath(path string) FileApiLoaderOption {
+	return func(f *FileApiLoader) {
+		f.path = path
+	}
+}
+
+// FileApiLoader loads apis from a single local file, picking a codec by
+// its extension (.yaml/.yml/.json/.toml/.pb), and watches it with
+// fsnotify for changes.
+type FileApiLoader struct {
+	path string
+
+	lock sync.RWMutex
+	apis []model.Api
+}
+
+// NewFileApiLoader builds a FileApiLoader, applying any FileApiLoaderOption.
+func NewFileApiLoader(opts ...FileApiLoaderOption) *FileApiLoader {
+	f := &FileApiLoader{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *FileApiLoader) InitLoad() error {
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	c, err := codec.ForFileExtension(strings.TrimPrefix(filepath.Ext(f.path), "."))
+	if err != nil {
+		return err
+	}
+	apis, err := c.DecodeList(data)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	f.apis = apis
+	f.lock.Unlock()
+	return nil
+}
+
+func (f *FileApiLoader) HotLoad() (chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(f.path); err != nil {
+		return nil, err
+	}
+
+	changeNotifier := make(chan struct{}, 1)
+	go func() {
+		defer close(changeNotifier)
+		defer watcher.Close()
+		for event := range watcher.Events {
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := f.InitLoad(); err != nil {
+				logger.Errorf("file api loader: reload %s error:%v", f.path, err)
+				continue
+			}
+			select {
+			case changeNotifier <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changeNotifier, nil
+}
+
+func (f *FileApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.apis, nil
+}
+
+func (f *FileApiLoader) GetPrior() int {
+	return 1
+}
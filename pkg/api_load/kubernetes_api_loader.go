@@ -0,0 +1,240 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"context"
+	"sync"
+)
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+// apiDefinitionGVR identifies the cluster-scoped ApiDefinition CRD; see
+// deploy/kubernetes/apidefinition-crd.yaml for its schema.
+var apiDefinitionGVR = schema.GroupVersionResource{
+	Group:    "proxy.dubbogo.apache.org",
+	Version:  "v1alpha1",
+	Resource: "apidefinitions",
+}
+
+func init() {
+	RegisterLoaderFactory("kubernetes", newKubernetesApiLoaderFromConfig)
+}
+
+func newKubernetesApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	opts := []KubernetesApiLoaderOption{WithKubeconfigPath(cfg.Properties["kubeconfig"])}
+	if selector := cfg.Properties["selector"]; selector != "" {
+		opts = append(opts, WithLabelSelector(selector))
+	}
+	return NewKubernetesApiLoader(opts...)
+}
+
+// KubernetesApiLoaderOption configures a KubernetesApiLoader.
+type KubernetesApiLoaderOption func(*KubernetesApiLoader)
+
+// WithKubeconfigPath sets the kubeconfig file to build the client from;
+// empty uses in-cluster config, the way client-go normally resolves it
+// when run as a pod.
+func WithKubeconfigPath(path string) KubernetesApiLoaderOption {
+	return func(k *KubernetesApiLoader) {
+		k.kubeconfigPath = path
+	}
+}
+
+// WithLabelSelector restricts the watched ApiDefinitions to those
+// matching selector, e.g. "env=prod".
+func WithLabelSelector(selector string) KubernetesApiLoaderOption {
+	return func(k *KubernetesApiLoader) {
+		k.labelSelector = selector
+	}
+}
+
+// KubernetesApiLoader watches the cluster-scoped ApiDefinition CRD
+// (proxy.dubbogo.apache.org/v1alpha1) via client-go's dynamic informer
+// and translates each object's spec into a model.Api.
+type KubernetesApiLoader struct {
+	kubeconfigPath string
+	labelSelector  string
+	client         dynamic.Interface
+	stopCh         chan struct{}
+
+	lock sync.RWMutex
+	apis map[string]model.Api // keyed by object name; the CRD is cluster-scoped
+}
+
+// stopInformer returns the channel that stops the informer started by
+// HotLoad; it is never closed, since ApiLoader has no Stop method and the
+// informer is meant to run for the process lifetime.
+func (k *KubernetesApiLoader) stopInformer() chan struct{} {
+	return k.stopCh
+}
+
+// NewKubernetesApiLoader builds a KubernetesApiLoader, applying any
+// KubernetesApiLoaderOption.
+func NewKubernetesApiLoader(opts ...KubernetesApiLoaderOption) (*KubernetesApiLoader, error) {
+	k := &KubernetesApiLoader{apis: make(map[string]model.Api, 8), stopCh: make(chan struct{})}
+	for _, opt := range opts {
+		opt(k)
+	}
+	restConfig, err := clientcmd.BuildConfigFromFlags("", k.kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	k.client = client
+	return k, nil
+}
+
+func (k *KubernetesApiLoader) InitLoad() error {
+	list, err := k.client.Resource(apiDefinitionGVR).List(context.Background(), metav1.ListOptions{LabelSelector: k.labelSelector})
+	if err != nil {
+		return err
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	for i := range list.Items {
+		k.setLocked(&list.Items[i])
+	}
+	return nil
+}
+
+func (k *KubernetesApiLoader) HotLoad() (chan struct{}, error) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(k.client, 0, metav1.NamespaceAll,
+		func(opts *metav1.ListOptions) { opts.LabelSelector = k.labelSelector })
+	informer := factory.ForResource(apiDefinitionGVR).Informer()
+
+	changeNotifier := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changeNotifier <- struct{}{}:
+		default:
+		}
+	}
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			k.upsert(obj)
+			notify()
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			k.upsert(obj)
+			notify()
+		},
+		DeleteFunc: func(obj interface{}) {
+			k.remove(obj)
+			notify()
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go informer.Run(k.stopInformer())
+	return changeNotifier, nil
+}
+
+func (k *KubernetesApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	apis := make([]model.Api, 0, len(k.apis))
+	for _, api := range k.apis {
+		apis = append(apis, api)
+	}
+	return apis, nil
+}
+
+func (k *KubernetesApiLoader) GetPrior() int {
+	return 40
+}
+
+// setLocked decodes obj's spec into a model.Api and stores it, assuming
+// k.lock is already held for writing.
+func (k *KubernetesApiLoader) setLocked(obj *unstructured.Unstructured) {
+	api, ok := apiDefinitionToApi(obj)
+	if !ok {
+		delete(k.apis, obj.GetName())
+		return
+	}
+	k.apis[obj.GetName()] = api
+}
+
+func (k *KubernetesApiLoader) upsert(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		logger.Warnf("kubernetes api loader: unexpected informer object type %T", obj)
+		return
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.setLocked(u)
+}
+
+func (k *KubernetesApiLoader) remove(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			logger.Warnf("kubernetes api loader: unexpected informer object type %T", obj)
+			return
+		}
+		u, ok = tombstone.Obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	delete(k.apis, u.GetName())
+}
+
+// apiDefinitionToApi translates a single ApiDefinition object's spec into
+// a model.Api, gating on .status.phase the same way model.Up/model.Down
+// gates a loaded api: an ApiDefinition that hasn't reached phase "Up" yet
+// is treated as not loaded at all.
+func apiDefinitionToApi(obj *unstructured.Unstructured) (model.Api, bool) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if phase != "Up" {
+		return model.Api{}, false
+	}
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "name")
+	iTypeStr, _, _ := unstructured.NestedString(obj.Object, "spec", "itypeStr")
+	oTypeStr, _, _ := unstructured.NestedString(obj.Object, "spec", "otypeStr")
+	method, _, _ := unstructured.NestedString(obj.Object, "spec", "method")
+	return model.Api{
+		Name:     name,
+		ITypeStr: iTypeStr,
+		OTypeStr: oTypeStr,
+		Method:   method,
+		Status:   model.Up,
+	}, true
+}
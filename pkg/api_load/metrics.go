@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for ApiManager's debounced hot-reload path, so operators can
+// tell a healthy quiet registry apart from one that's thrashing or
+// stuck failing merges.
+var (
+	mergesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dubbogo_proxy",
+		Subsystem: "api_load",
+		Name:      "merges_total",
+		Help:      "Total number of api merge cycles that completed and published successfully.",
+	})
+	mergesSkippedFrequency = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dubbogo_proxy",
+		Subsystem: "api_load",
+		Name:      "merges_skipped_frequency",
+		Help:      "Merge cycles skipped because a previous merge was still in flight.",
+	})
+	notificationsCoalesced = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dubbogo_proxy",
+		Subsystem: "api_load",
+		Name:      "notifications_coalesced",
+		Help:      "Loader change notifications folded into an already-pending merge instead of queuing a new one.",
+	})
+	mergeDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "dubbogo_proxy",
+		Subsystem: "api_load",
+		Name:      "merge_duration_seconds",
+		Help:      "How long a single api merge cycle took.",
+	})
+	lastMergeErrorTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dubbogo_proxy",
+		Subsystem: "api_load",
+		Name:      "last_merge_error_timestamp",
+		Help:      "Unix timestamp of the last failed merge cycle, 0 if none have failed yet.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		mergesTotal,
+		mergesSkippedFrequency,
+		notificationsCoalesced,
+		mergeDurationSeconds,
+		lastMergeErrorTimestamp,
+	)
+}
@@ -0,0 +1,184 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"net"
+	"strconv"
+	"sync"
+)
+
+import (
+	"github.com/nacos-group/nacos-sdk-go/clients"
+	"github.com/nacos-group/nacos-sdk-go/clients/config_client"
+	"github.com/nacos-group/nacos-sdk-go/common/constant"
+	"github.com/nacos-group/nacos-sdk-go/vo"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/api_load/codec"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+const (
+	defaultNacosDataID      = "dubbo-go-proxy-apis"
+	defaultNacosGroup       = "DEFAULT_GROUP"
+	defaultNacosContentType = "application/yaml"
+	defaultNacosPort        = 8848
+)
+
+func init() {
+	RegisterLoaderFactory("nacos", newNacosApiLoaderFromConfig)
+}
+
+func newNacosApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	return NewNacosApiLoader(
+		WithNacosAddress(cfg.Properties["address"]),
+		WithNacosContentType(cfg.Properties["content-type"]),
+	), nil
+}
+
+// NacosApiLoaderOption configures a NacosApiLoader.
+type NacosApiLoaderOption func(*NacosApiLoader)
+
+// WithNacosAddress sets the nacos server address (host:port) to connect to;
+// a bare host uses port 8848.
+func WithNacosAddress(address string) NacosApiLoaderOption {
+	return func(n *NacosApiLoader) {
+		n.address = address
+	}
+}
+
+// WithNacosContentType sets the content-type the config item's apis are
+// encoded with; an empty value defaults to YAML.
+func WithNacosContentType(contentType string) NacosApiLoaderOption {
+	return func(n *NacosApiLoader) {
+		n.contentType = contentType
+	}
+}
+
+// NacosApiLoader loads apis from a single nacos config item and listens
+// for changes to it via the nacos config_client.
+type NacosApiLoader struct {
+	address     string
+	contentType string
+	client      config_client.IConfigClient
+
+	lock sync.RWMutex
+	apis []model.Api
+}
+
+// NewNacosApiLoader builds a NacosApiLoader, applying any NacosApiLoaderOption.
+func NewNacosApiLoader(opts ...NacosApiLoaderOption) *NacosApiLoader {
+	n := &NacosApiLoader{}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+func (n *NacosApiLoader) ensureClient() error {
+	if n.client != nil {
+		return nil
+	}
+	host, port := n.address, uint64(defaultNacosPort)
+	if h, p, err := net.SplitHostPort(n.address); err == nil {
+		parsed, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return err
+		}
+		host, port = h, parsed
+	}
+	client, err := clients.CreateConfigClient(map[string]interface{}{
+		"serverConfigs": []constant.ServerConfig{{IpAddr: host, Port: port}},
+		"clientConfig":  constant.ClientConfig{},
+	})
+	if err != nil {
+		return err
+	}
+	n.client = client
+	return nil
+}
+
+// decode parses a nacos config item's content according to n.contentType,
+// defaulting to YAML when none was configured.
+func (n *NacosApiLoader) decode(content string) ([]model.Api, error) {
+	contentType := n.contentType
+	if contentType == "" {
+		contentType = defaultNacosContentType
+	}
+	c, err := codec.ForContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.DecodeList([]byte(content))
+}
+
+func (n *NacosApiLoader) InitLoad() error {
+	if err := n.ensureClient(); err != nil {
+		return err
+	}
+	content, err := n.client.GetConfig(vo.ConfigParam{DataId: defaultNacosDataID, Group: defaultNacosGroup})
+	if err != nil {
+		return err
+	}
+	apis, err := n.decode(content)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	n.apis = apis
+	n.lock.Unlock()
+	return nil
+}
+
+func (n *NacosApiLoader) HotLoad() (chan struct{}, error) {
+	changeNotifier := make(chan struct{}, 1)
+	err := n.client.ListenConfig(vo.ConfigParam{
+		DataId: defaultNacosDataID,
+		Group:  defaultNacosGroup,
+		OnChange: func(namespace, group, dataId, data string) {
+			apis, err := n.decode(data)
+			if err != nil {
+				logger.Errorf("nacos api loader: decode config change error:%v", err)
+				return
+			}
+			n.lock.Lock()
+			n.apis = apis
+			n.lock.Unlock()
+			select {
+			case changeNotifier <- struct{}{}:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changeNotifier, nil
+}
+
+func (n *NacosApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.apis, nil
+}
+
+func (n *NacosApiLoader) GetPrior() int {
+	return 2
+}
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_load
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+import (
+	"github.com/apache/dubbo-go/common/logger"
+	"github.com/dubbogo/dubbo-go-proxy/pkg/model"
+)
+
+func init() {
+	RegisterLoaderFactory("zookeeper", newZookeeperApiLoaderFromConfig)
+}
+
+func newZookeeperApiLoaderFromConfig(cfg model.LoaderConfig) (ApiLoader, error) {
+	servers := strings.Split(cfg.Properties["address"], ",")
+	return NewZookeeperApiLoader(servers, cfg.Properties["znode"])
+}
+
+// ZookeeperApiLoader loads apis from the children of a single znode, one
+// api per child, and keeps watching that znode for added/removed children.
+type ZookeeperApiLoader struct {
+	conn  *zk.Conn
+	znode string
+
+	lock sync.RWMutex
+	apis []model.Api
+}
+
+// NewZookeeperApiLoader connects to the given zookeeper servers and
+// prepares to load apis stored as children of znode.
+func NewZookeeperApiLoader(servers []string, znode string) (*ZookeeperApiLoader, error) {
+	conn, _, err := zk.Connect(servers, time.Second*10)
+	if err != nil {
+		return nil, err
+	}
+	return &ZookeeperApiLoader{conn: conn, znode: znode}, nil
+}
+
+// loadChildren reads every child of znode and also returns the watch
+// channel fired on the next children-set change.
+func (z *ZookeeperApiLoader) loadChildren() ([]model.Api, <-chan zk.Event, error) {
+	children, _, watch, err := z.conn.ChildrenW(z.znode)
+	if err != nil {
+		return nil, nil, err
+	}
+	apis := make([]model.Api, 0, len(children))
+	for _, child := range children {
+		path := z.znode + "/" + child
+		data, _, err := z.conn.Get(path)
+		if err != nil {
+			logger.Warnf("zookeeper api loader: get znode %s error:%v", path, err)
+			continue
+		}
+		var api model.Api
+		if err := decodeApiPayload(data, &api); err != nil {
+			logger.Warnf("zookeeper api loader: skip undecodable znode %s: %v", path, err)
+			continue
+		}
+		apis = append(apis, api)
+	}
+	return apis, watch, nil
+}
+
+func (z *ZookeeperApiLoader) InitLoad() error {
+	apis, _, err := z.loadChildren()
+	if err != nil {
+		return err
+	}
+	z.lock.Lock()
+	z.apis = apis
+	z.lock.Unlock()
+	return nil
+}
+
+// HotLoad watches znode's children for changes. A transient error talking
+// to zookeeper is retried with backoff instead of ending the watch: a
+// brief zookeeper outage shouldn't permanently disable hot-reload for
+// this source until the process restarts.
+func (z *ZookeeperApiLoader) HotLoad() (chan struct{}, error) {
+	changeNotifier := make(chan struct{}, 1)
+	go func() {
+		defer close(changeNotifier)
+		attempt := 0
+		for {
+			apis, watch, err := z.loadChildren()
+			if err != nil {
+				delay := watchBackoff(attempt)
+				attempt++
+				logger.Errorf("zookeeper api loader: watch children of %s error, retrying in %s: %v", z.znode, delay, err)
+				time.Sleep(delay)
+				continue
+			}
+			attempt = 0
+			z.lock.Lock()
+			z.apis = apis
+			z.lock.Unlock()
+
+			select {
+			case changeNotifier <- struct{}{}:
+			default:
+			}
+			<-watch
+		}
+	}()
+	return changeNotifier, nil
+}
+
+func (z *ZookeeperApiLoader) GetLoadedApiConfigs() ([]model.Api, error) {
+	z.lock.RLock()
+	defer z.lock.RUnlock()
+	return z.apis, nil
+}
+
+func (z *ZookeeperApiLoader) GetPrior() int {
+	return 30
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package model
+
+// Status is the lifecycle state of an Api.
+type Status int32
+
+const (
+	// Up means the api is enabled and should be served.
+	Up Status = iota
+	// Down means the api is disabled and should be removed from serving.
+	Down
+)
+
+// Api describes a single route exposed by the proxy, as loaded from any
+// ApiLoader (file, nacos, etc).
+type Api struct {
+	Name     string `yaml:"name" json:"name"`
+	ITypeStr string `yaml:"itypeStr" json:"itypeStr"`
+	OTypeStr string `yaml:"otypeStr" json:"otypeStr"`
+	Method   string `yaml:"method" json:"method"`
+	Status   Status `yaml:"status" json:"status"`
+}
+
+// FileConfig configures the file-based ApiLoader.
+type FileConfig struct {
+	FileApiConfPath string `yaml:"fileApiConfPath" json:"fileApiConfPath"`
+}
+
+// NacosConfig configures the nacos-based ApiLoader.
+type NacosConfig struct {
+	Address string `yaml:"address" json:"address"`
+}
+
+// KubernetesConfig configures the kubernetes CRD-based ApiLoader.
+type KubernetesConfig struct {
+	KubeconfigPath string `yaml:"kubeconfigPath" json:"kubeconfigPath"`
+	Namespace      string `yaml:"namespace" json:"namespace"`
+	LabelSelector  string `yaml:"labelSelector" json:"labelSelector"`
+}
+
+// ApiConfig is the top-level configuration for all ApiLoaders an
+// ApiManager may load apis from.
+type ApiConfig struct {
+	File       *FileConfig       `yaml:"file" json:"file"`
+	Nacos      *NacosConfig      `yaml:"nacos" json:"nacos"`
+	Kubernetes *KubernetesConfig `yaml:"kubernetes" json:"kubernetes"`
+	// Loaders configures any ApiLoader registered via
+	// api_load.RegisterLoaderFactory, e.g. etcd/consul/zookeeper/kubernetes.
+	Loaders []LoaderConfig `yaml:"loaders" json:"loaders"`
+}
+
+// LoaderConfig is the generic configuration for a single ApiLoader
+// instance. Name selects which registered factory builds the loader;
+// Properties carries whatever settings that factory needs (addresses,
+// watch prefixes, file paths, ...).
+type LoaderConfig struct {
+	Name       string            `yaml:"name" json:"name"`
+	Properties map[string]string `yaml:"properties" json:"properties"`
+	// Priority overrides the ApiLoader's own GetPrior() for merge
+	// ordering; nil (the field absent from config) means "use the
+	// loader's built-in default". A pointer so an operator can
+	// deliberately set 0 without it being mistaken for "unset".
+	Priority *int `yaml:"priority" json:"priority"`
+	// MergeStrategy controls how this source's apis are combined with
+	// other sources' on a buildApiID conflict; empty defaults to Override.
+	MergeStrategy MergeStrategy `yaml:"mergeStrategy" json:"mergeStrategy"`
+}
+
+// MergeStrategy says how one source's api should be combined with
+// another source's api that merged to the same buildApiID.
+type MergeStrategy string
+
+const (
+	// MergeOverride replaces whatever lower-priority source already
+	// published this id. This is the historical, implicit behavior.
+	MergeOverride MergeStrategy = "override"
+	// MergeFallback only fills the id in if no other source has published
+	// it yet; it never replaces an existing entry, even one from a
+	// lower-priority source.
+	MergeFallback MergeStrategy = "fallback"
+	// MergeReject refuses to replace an existing entry and instead
+	// records the id as a conflict, so operators can see whose config
+	// actually lost.
+	MergeReject MergeStrategy = "reject"
+	// MergeUnion keeps both this source's api and the existing one,
+	// publishing this source's under a source-qualified id instead of
+	// dropping either.
+	MergeUnion MergeStrategy = "union"
+)
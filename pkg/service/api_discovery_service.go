@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+// DiscoveryRequest wraps the encoded payload of a single api mutation sent
+// to an ApiDiscoveryService.
+type DiscoveryRequest struct {
+	Data []byte
+}
+
+// NewDiscoveryRequest builds a DiscoveryRequest from an already-encoded
+// api payload.
+func NewDiscoveryRequest(data []byte) *DiscoveryRequest {
+	return &DiscoveryRequest{Data: data}
+}
+
+// ApiDiscoveryService is the sink that merged apis get published to, e.g.
+// the in-memory router or a registry-backed discovery client.
+type ApiDiscoveryService interface {
+	// AddApi publishes a brand-new api and returns an implementation
+	// defined id/revision for it.
+	AddApi(request DiscoveryRequest) (string, error)
+	// UpdateApi republishes an api that was already added under the same
+	// buildApiID, without requiring a remove+add round trip.
+	UpdateApi(request DiscoveryRequest) error
+	// RemoveApi removes a single previously published api by its
+	// buildApiID.
+	RemoveApi(id string) error
+	// RemoveAllApi drops every api currently published.
+	RemoveAllApi() error
+}